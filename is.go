@@ -1,9 +1,14 @@
 package is
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"math"
 	"reflect"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 )
@@ -17,6 +22,7 @@ type Is struct {
 	strict     bool
 	failFormat string
 	failArgs   []interface{}
+	diff       bool
 }
 
 // New creates a new instance of the Is object and stores a reference to the
@@ -25,7 +31,7 @@ func New(tb testing.TB) *Is {
 	if tb == nil {
 		log.Fatalln("You must provide a testing object.")
 	}
-	return &Is{TB: tb, strict: true}
+	return &Is{TB: tb, strict: true, diff: true}
 }
 
 // New creates a new copy of your Is object and replaces the internal testing
@@ -44,6 +50,7 @@ func (is *Is) New(tb testing.TB) *Is {
 		strict:     is.strict,
 		failFormat: is.failFormat,
 		failArgs:   is.failArgs,
+		diff:       is.diff,
 	}
 }
 
@@ -55,6 +62,7 @@ func (is *Is) Msg(format string, args ...interface{}) *Is {
 		strict:     is.strict,
 		failFormat: format,
 		failArgs:   args,
+		diff:       is.diff,
 	}
 }
 
@@ -78,6 +86,7 @@ func (is *Is) AddMsg(format string, args ...interface{}) *Is {
 		strict:     is.strict,
 		failFormat: fmt.Sprintf("%s - %s", is.failFormat, format),
 		failArgs:   append(is.failArgs, args...),
+		diff:       is.diff,
 	}
 }
 
@@ -90,6 +99,7 @@ func (is *Is) Lax() *Is {
 		strict:     false,
 		failFormat: is.failFormat,
 		failArgs:   is.failArgs,
+		diff:       is.diff,
 	}
 }
 
@@ -102,6 +112,22 @@ func (is *Is) Strict() *Is {
 		strict:     true,
 		failFormat: is.failFormat,
 		failArgs:   is.failArgs,
+		diff:       is.diff,
+	}
+}
+
+// WithDiff returns a copy of this instance of Is with rich, diff-based
+// failure output enabled or disabled. When enabled (the default), failures
+// from Equal, NotEqual and OneOf that compare multi-line strings, structs,
+// slices or maps include a unified diff of the pretty-printed values, when
+// those renderings differ, in addition to the usual got/expected summary.
+func (is *Is) WithDiff(enabled bool) *Is {
+	return &Is{
+		TB:         is.TB,
+		strict:     is.strict,
+		failFormat: is.failFormat,
+		failArgs:   is.failArgs,
+		diff:       enabled,
 	}
 }
 
@@ -114,9 +140,10 @@ func (is *Is) Strict() *Is {
 func (is *Is) Equal(actual interface{}, expected interface{}) {
 	is.TB.Helper()
 	if !isEqual(actual, expected) {
-		fail(is, "got %v (%s). expected %v (%s)",
+		fail(is, "got %v (%s). expected %v (%s)%s",
 			actual, objectTypeName(actual),
-			expected, objectTypeName(expected))
+			expected, objectTypeName(expected),
+			is.diffSuffix(actual, expected))
 	}
 }
 
@@ -129,9 +156,10 @@ func (is *Is) Equal(actual interface{}, expected interface{}) {
 func (is *Is) NotEqual(a interface{}, b interface{}) {
 	is.TB.Helper()
 	if isEqual(a, b) {
-		fail(is, "expected objects '%s' and '%s' not to be equal",
+		fail(is, "expected objects '%s' and '%s' not to be equal%s",
 			objectTypeName(a),
-			objectTypeName(b))
+			objectTypeName(b),
+			is.diffSuffix(a, b))
 	}
 }
 
@@ -152,9 +180,14 @@ func (is *Is) OneOf(a interface{}, b ...interface{}) {
 		}
 	}
 	if !result {
-		fail(is, "expected object '%s' to be equal to one of '%s', but got: %v and %v",
+		suffix := ""
+		if diffable(a) {
+			suffix = is.diffSuffix(a, b)
+		}
+		fail(is, "expected object '%s' to be equal to one of '%s', but got: %v and %v%s",
 			objectTypeName(a),
-			objectTypeNames(b), a, b)
+			objectTypeNames(b), a, b,
+			suffix)
 	}
 }
 
@@ -181,6 +214,158 @@ func (is *Is) NotOneOf(a interface{}, b ...interface{}) {
 	}
 }
 
+// InDelta checks that actual and expected are numeric values (of any
+// numeric kind) whose absolute difference is no greater than delta. It
+// fails if either value is NaN.
+func (is *Is) InDelta(actual interface{}, expected interface{}, delta float64) {
+	is.TB.Helper()
+	a, aOK := toFloat64(actual)
+	e, eOK := toFloat64(expected)
+	if !aOK || !eOK {
+		fail(is, "InDelta requires numeric values, got %s and %s", objectTypeName(actual), objectTypeName(expected))
+		return
+	}
+	if math.IsNaN(a) || math.IsNaN(e) {
+		fail(is, "InDelta does not support NaN, got %v and %v", actual, expected)
+		return
+	}
+	if a == e {
+		return
+	}
+	if diff := math.Abs(a - e); diff > delta {
+		fail(is, "expected %v to be within delta %v of %v, but difference was %v", actual, delta, expected, diff)
+	}
+}
+
+// InEpsilon checks that actual and expected are numeric values (of any
+// numeric kind) whose relative difference, |actual-expected|/|expected|,
+// is no greater than epsilon. It fails if either value is NaN, or if
+// expected is zero (relative difference is undefined).
+func (is *Is) InEpsilon(actual interface{}, expected interface{}, epsilon float64) {
+	is.TB.Helper()
+	a, aOK := toFloat64(actual)
+	e, eOK := toFloat64(expected)
+	if !aOK || !eOK {
+		fail(is, "InEpsilon requires numeric values, got %s and %s", objectTypeName(actual), objectTypeName(expected))
+		return
+	}
+	if math.IsNaN(a) || math.IsNaN(e) {
+		fail(is, "InEpsilon does not support NaN, got %v and %v", actual, expected)
+		return
+	}
+	if e == 0 {
+		fail(is, "InEpsilon cannot compute a relative difference against an expected value of zero")
+		return
+	}
+	if a == e {
+		return
+	}
+	if rel := math.Abs(a-e) / math.Abs(e); rel > epsilon {
+		fail(is, "expected %v to be within epsilon %v of %v, but relative difference was %v", actual, epsilon, expected, rel)
+	}
+}
+
+// InDeltaSlice checks that actual and expected are slices or arrays of
+// equal length whose elements are pairwise within delta of one another,
+// as defined by InDelta. It reports the first offending index.
+func (is *Is) InDeltaSlice(actual interface{}, expected interface{}, delta float64) {
+	is.TB.Helper()
+	av, ev, ok := numericSlices(is, actual, expected)
+	if !ok {
+		return
+	}
+	for i := 0; i < av.Len(); i++ {
+		a, aOK := toFloat64(av.Index(i).Interface())
+		e, eOK := toFloat64(ev.Index(i).Interface())
+		if !aOK || !eOK {
+			fail(is, "InDeltaSlice requires numeric elements, found at index %d: %s and %s", i, objectTypeName(av.Index(i).Interface()), objectTypeName(ev.Index(i).Interface()))
+			return
+		}
+		if math.IsNaN(a) || math.IsNaN(e) {
+			fail(is, "InDeltaSlice does not support NaN, found at index %d: %v and %v", i, av.Index(i).Interface(), ev.Index(i).Interface())
+			return
+		}
+		if a == e {
+			continue
+		}
+		if diff := math.Abs(a - e); diff > delta {
+			fail(is, "expected element %d (%v) to be within delta %v of %v, but difference was %v", i, av.Index(i).Interface(), delta, ev.Index(i).Interface(), diff)
+			return
+		}
+	}
+}
+
+// InEpsilonSlice checks that actual and expected are slices or arrays of
+// equal length whose elements are pairwise within epsilon of one
+// another, as defined by InEpsilon. It reports the first offending index.
+func (is *Is) InEpsilonSlice(actual interface{}, expected interface{}, epsilon float64) {
+	is.TB.Helper()
+	av, ev, ok := numericSlices(is, actual, expected)
+	if !ok {
+		return
+	}
+	for i := 0; i < av.Len(); i++ {
+		a, aOK := toFloat64(av.Index(i).Interface())
+		e, eOK := toFloat64(ev.Index(i).Interface())
+		if !aOK || !eOK {
+			fail(is, "InEpsilonSlice requires numeric elements, found at index %d: %s and %s", i, objectTypeName(av.Index(i).Interface()), objectTypeName(ev.Index(i).Interface()))
+			return
+		}
+		if math.IsNaN(a) || math.IsNaN(e) {
+			fail(is, "InEpsilonSlice does not support NaN, found at index %d: %v and %v", i, av.Index(i).Interface(), ev.Index(i).Interface())
+			return
+		}
+		if e == 0 {
+			fail(is, "InEpsilonSlice cannot compute a relative difference against an expected value of zero at index %d", i)
+			return
+		}
+		if a == e {
+			continue
+		}
+		if rel := math.Abs(a-e) / math.Abs(e); rel > epsilon {
+			fail(is, "expected element %d (%v) to be within epsilon %v of %v, but relative difference was %v", i, av.Index(i).Interface(), epsilon, ev.Index(i).Interface(), rel)
+			return
+		}
+	}
+}
+
+// numericSlices validates that actual and expected are same-length slices
+// or arrays and returns their reflect.Value so callers can iterate
+// elements. It calls fail (via the provided is) and returns ok=false when
+// the inputs don't meet those requirements.
+func numericSlices(is *Is, actual, expected interface{}) (av, ev reflect.Value, ok bool) {
+	is.TB.Helper()
+	av = reflect.ValueOf(actual)
+	ev = reflect.ValueOf(expected)
+	if !av.IsValid() || !ev.IsValid() ||
+		(av.Kind() != reflect.Slice && av.Kind() != reflect.Array) ||
+		(ev.Kind() != reflect.Slice && ev.Kind() != reflect.Array) {
+		fail(is, "expected and actual must both be slices or arrays, got %s and %s", objectTypeName(actual), objectTypeName(expected))
+		return av, ev, false
+	}
+	if av.Len() != ev.Len() {
+		fail(is, "expected slices of equal length, got %d and %d", av.Len(), ev.Len())
+		return av, ev, false
+	}
+	return av, ev, true
+}
+
+// toFloat64 converts v to a float64 if it is a numeric kind (any int,
+// uint or float type), reporting ok=false otherwise.
+func toFloat64(v interface{}) (f float64, ok bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	default:
+		return 0, false
+	}
+}
+
 // Err checks the provided error object to determine if an error is present.
 func (is *Is) Err(e error) {
 	is.TB.Helper()
@@ -198,6 +383,43 @@ func (is *Is) NotErr(e error) {
 	}
 }
 
+// ErrorIs checks that err or any error it wraps matches target, as
+// determined by errors.Is. It fails unless errors.Is(err, target) is true.
+func (is *Is) ErrorIs(err error, target error) {
+	is.TB.Helper()
+	if !errors.Is(err, target) {
+		fail(is, "expected error chain %v to match target error %v", err, target)
+	}
+}
+
+// ErrorAs checks that err or any error it wraps can be assigned to
+// target, as determined by errors.As. target must be a non-nil pointer
+// to either a type implementing error, or an interface type.
+func (is *Is) ErrorAs(err error, target interface{}) {
+	is.TB.Helper()
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		fail(is, "ErrorAs requires target to be a non-nil pointer, got %s", objectTypeName(target))
+		return
+	}
+	if !errors.As(err, target) {
+		fail(is, "expected error chain %v to contain an error assignable to %s", err, objectTypeName(target))
+	}
+}
+
+// ErrorContains checks that err is non-nil and its message contains
+// substr.
+func (is *Is) ErrorContains(err error, substr string) {
+	is.TB.Helper()
+	if err == nil {
+		fail(is, "expected an error containing %q, but got no error", substr)
+		return
+	}
+	if !strings.Contains(err.Error(), substr) {
+		fail(is, "expected error %q to contain %q", err.Error(), substr)
+	}
+}
+
 // Nil checks the provided object to determine if it is nil.
 func (is *Is) Nil(o interface{}) {
 	is.TB.Helper()
@@ -285,6 +507,191 @@ func (is *Is) Len(o interface{}, l int) {
 	}
 }
 
+// Contains checks that container holds element: a substring of a string, a
+// member of a slice or array (compared with the same deep-compare rules as
+// Equal), or a key of a map.
+func (is *Is) Contains(container interface{}, element interface{}) {
+	is.TB.Helper()
+	found, ok := contains(container, element)
+	if !ok {
+		fail(is, "cannot check Contains on object '%s', expected a string, slice, array or map", objectTypeName(container))
+		return
+	}
+	if !found {
+		fail(is, "expected '%s' to contain %v, but it did not", objectTypeName(container), element)
+	}
+}
+
+// NotContains checks that container does not hold element, using the same
+// rules as Contains.
+func (is *Is) NotContains(container interface{}, element interface{}) {
+	is.TB.Helper()
+	found, ok := contains(container, element)
+	if !ok {
+		fail(is, "cannot check NotContains on object '%s', expected a string, slice, array or map", objectTypeName(container))
+		return
+	}
+	if found {
+		fail(is, "expected '%s' not to contain %v, but it did", objectTypeName(container), element)
+	}
+}
+
+// contains implements the shared logic behind Contains and NotContains. ok
+// is false when container is not a string, slice, array or map.
+func contains(container interface{}, element interface{}) (found bool, ok bool) {
+	if s, isString := container.(string); isString {
+		sub, isSubString := element.(string)
+		if !isSubString {
+			return false, true
+		}
+		return strings.Contains(s, sub), true
+	}
+
+	v := reflect.ValueOf(container)
+	if !v.IsValid() {
+		return false, false
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if isEqual(v.Index(i).Interface(), element) {
+				return true, true
+			}
+		}
+		return false, true
+	case reflect.Map:
+		for _, k := range v.MapKeys() {
+			if isEqual(k.Interface(), element) {
+				return true, true
+			}
+		}
+		return false, true
+	default:
+		return false, false
+	}
+}
+
+// ElementsMatch checks that listA and listB contain the same elements
+// regardless of order, treating them as multisets: each element in listA
+// must pair with a distinct, still-unmatched element in listB using the
+// same deep-compare rules as Equal. It fails when any element cannot be
+// paired, reporting the unmatched elements on each side.
+func (is *Is) ElementsMatch(listA interface{}, listB interface{}) {
+	is.TB.Helper()
+	a := reflect.ValueOf(listA)
+	b := reflect.ValueOf(listB)
+	if !a.IsValid() || !b.IsValid() ||
+		(a.Kind() != reflect.Slice && a.Kind() != reflect.Array) ||
+		(b.Kind() != reflect.Slice && b.Kind() != reflect.Array) {
+		fail(is, "ElementsMatch requires two slices or arrays, got %s and %s", objectTypeName(listA), objectTypeName(listB))
+		return
+	}
+
+	matched := make([]bool, b.Len())
+	var extraA []interface{}
+	for i := 0; i < a.Len(); i++ {
+		ai := a.Index(i).Interface()
+		found := false
+		for j := 0; j < b.Len(); j++ {
+			if matched[j] {
+				continue
+			}
+			if isEqual(ai, b.Index(j).Interface()) {
+				matched[j] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			extraA = append(extraA, ai)
+		}
+	}
+
+	var extraB []interface{}
+	for j := 0; j < b.Len(); j++ {
+		if !matched[j] {
+			extraB = append(extraB, b.Index(j).Interface())
+		}
+	}
+
+	if len(extraA) > 0 || len(extraB) > 0 {
+		fail(is, "expected elements to match: missing from listB: %v; missing from listA: %v", extraA, extraB)
+	}
+}
+
+// Subset checks that every element of sub is present in super: for slices
+// and arrays, every element of sub must appear in super (compared with the
+// same rules as Equal); for maps, every key of sub must be present in
+// super with an equal value.
+func (is *Is) Subset(super interface{}, sub interface{}) {
+	is.TB.Helper()
+	missing, ok := subsetDiff(super, sub)
+	if !ok {
+		fail(is, "Subset requires super and sub to both be slices/arrays or both be maps, got %s and %s", objectTypeName(super), objectTypeName(sub))
+		return
+	}
+	if len(missing) > 0 {
+		fail(is, "expected '%s' to be a subset of '%s', but these elements were missing: %v", objectTypeName(sub), objectTypeName(super), missing)
+	}
+}
+
+// NotSubset checks that sub is not a subset of super, using the same rules
+// as Subset.
+func (is *Is) NotSubset(super interface{}, sub interface{}) {
+	is.TB.Helper()
+	missing, ok := subsetDiff(super, sub)
+	if !ok {
+		fail(is, "NotSubset requires super and sub to both be slices/arrays or both be maps, got %s and %s", objectTypeName(super), objectTypeName(sub))
+		return
+	}
+	if len(missing) == 0 {
+		fail(is, "expected '%s' not to be a subset of '%s', but it was", objectTypeName(sub), objectTypeName(super))
+	}
+}
+
+// subsetDiff returns the elements of sub that are not found in super or,
+// for maps, the keys of sub that are absent from super or map to an unequal
+// value there. ok is false when super and sub aren't both slices/arrays or
+// both maps.
+func subsetDiff(super interface{}, sub interface{}) (missing []interface{}, ok bool) {
+	superV := reflect.ValueOf(super)
+	subV := reflect.ValueOf(sub)
+	if !superV.IsValid() || !subV.IsValid() {
+		return nil, false
+	}
+
+	if superV.Kind() == reflect.Map && subV.Kind() == reflect.Map {
+		for _, k := range subV.MapKeys() {
+			superVal := superV.MapIndex(k)
+			if !superVal.IsValid() || !isEqual(superVal.Interface(), subV.MapIndex(k).Interface()) {
+				missing = append(missing, k.Interface())
+			}
+		}
+		return missing, true
+	}
+
+	isSeq := func(v reflect.Value) bool {
+		return v.Kind() == reflect.Slice || v.Kind() == reflect.Array
+	}
+	if isSeq(superV) && isSeq(subV) {
+		for i := 0; i < subV.Len(); i++ {
+			found := false
+			for j := 0; j < superV.Len(); j++ {
+				if isEqual(subV.Index(i).Interface(), superV.Index(j).Interface()) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				missing = append(missing, subV.Index(i).Interface())
+			}
+		}
+		return missing, true
+	}
+
+	return nil, false
+}
+
 // ShouldPanic expects the provided function to panic. If the function does
 // not panic, this assertion fails.
 func (is *Is) ShouldPanic(f func()) {
@@ -298,6 +705,57 @@ func (is *Is) ShouldPanic(f func()) {
 	f()
 }
 
+// PanicsWithValue expects f to panic with a value equal to expected
+// (compared with the same deep-compare rules as Equal). It fails if f does
+// not panic, or panics with a different value.
+func (is *Is) PanicsWithValue(expected interface{}, f func()) {
+	is.TB.Helper()
+	didPanic := true
+	var recovered interface{}
+	func() {
+		defer func() {
+			recovered = recover()
+		}()
+		f()
+		didPanic = false
+	}()
+	if !didPanic {
+		fail(is, "expected function to panic with value %v, but it did not panic", expected)
+		return
+	}
+	if !isEqual(recovered, expected) {
+		fail(is, "expected function to panic with value %v, but it panicked with: %v", expected, recovered)
+	}
+}
+
+// PanicsWithError expects f to panic with a recovered value that is an
+// error whose message contains substr. It fails if f does not panic, or
+// panics with a value that isn't an error or doesn't match.
+func (is *Is) PanicsWithError(substr string, f func()) {
+	is.TB.Helper()
+	didPanic := true
+	var recovered interface{}
+	func() {
+		defer func() {
+			recovered = recover()
+		}()
+		f()
+		didPanic = false
+	}()
+	if !didPanic {
+		fail(is, "expected function to panic with an error containing %q, but it did not panic", substr)
+		return
+	}
+	err, ok := recovered.(error)
+	if !ok {
+		fail(is, "expected function to panic with an error, but it panicked with: %v", recovered)
+		return
+	}
+	if !strings.Contains(err.Error(), substr) {
+		fail(is, "expected function to panic with an error containing %q, but it panicked with: %v", substr, err)
+	}
+}
+
 // EqualType checks the type of the two provided objects and
 // fails if they are not the same.
 func (is *Is) EqualType(expected, actual interface{}) {
@@ -307,21 +765,481 @@ func (is *Is) EqualType(expected, actual interface{}) {
 	}
 }
 
-// WaitForTrue waits until the provided func returns true. If the timeout is
-// reached before the function returns true, the test will fail.
-func (is *Is) WaitForTrue(timeout time.Duration, f func() bool) {
+// yamlUnmarshal is the func used by YAMLEq to decode YAML documents. YAML
+// support is optional so this package doesn't pull in a YAML dependency;
+// call RegisterYAMLUnmarshal (typically from an init func in the package
+// that imports a YAML library) to enable YAMLEq.
+var yamlUnmarshal = func(data []byte, v interface{}) error {
+	return errors.New("yaml support not built in: call is.RegisterYAMLUnmarshal with a YAML unmarshal func")
+}
+
+// RegisterYAMLUnmarshal registers the unmarshal func that YAMLEq uses to
+// decode YAML documents, such as gopkg.in/yaml.v3's yaml.Unmarshal. Without
+// registration, YAMLEq fails with a "yaml support not built in" message.
+func RegisterYAMLUnmarshal(unmarshal func(data []byte, v interface{}) error) {
+	yamlUnmarshal = unmarshal
+}
+
+// JSONEq checks that actual and expected are structurally equal JSON
+// documents: it unmarshals both into interface{} trees and compares those
+// trees rather than the raw bytes, so formatting and key order don't
+// matter. On failure it reports the first differing JSON pointer path
+// (e.g. "/users/2/name") it can find.
+func (is *Is) JSONEq(actual string, expected string) {
+	is.TB.Helper()
+	var actualVal, expectedVal interface{}
+	if err := json.Unmarshal([]byte(actual), &actualVal); err != nil {
+		fail(is, "failed to parse actual as JSON: %v", err)
+		return
+	}
+	if err := json.Unmarshal([]byte(expected), &expectedVal); err != nil {
+		fail(is, "failed to parse expected as JSON: %v", err)
+		return
+	}
+	if !reflect.DeepEqual(actualVal, expectedVal) {
+		path := firstMismatchPath(expectedVal, actualVal, "")
+		fail(is, "expected JSON to be structurally equal, first difference at %s:\nactual:   %s\nexpected: %s", path, actual, expected)
+	}
+}
+
+// YAMLEq checks that actual and expected are structurally equal YAML
+// documents, comparing their decoded interface{} trees rather than the
+// raw bytes. It requires a YAML unmarshal func to be registered via
+// RegisterYAMLUnmarshal; otherwise it fails with a "yaml support not
+// built in" message.
+func (is *Is) YAMLEq(actual string, expected string) {
 	is.TB.Helper()
-	after := time.After(timeout)
+	var actualVal, expectedVal interface{}
+	if err := yamlUnmarshal([]byte(actual), &actualVal); err != nil {
+		fail(is, "failed to parse actual as YAML: %v", err)
+		return
+	}
+	if err := yamlUnmarshal([]byte(expected), &expectedVal); err != nil {
+		fail(is, "failed to parse expected as YAML: %v", err)
+		return
+	}
+	if !reflect.DeepEqual(actualVal, expectedVal) {
+		path := firstMismatchPath(expectedVal, actualVal, "")
+		fail(is, "expected YAML to be structurally equal, first difference at %s:\nactual:   %s\nexpected: %s", path, actual, expected)
+	}
+}
+
+// firstMismatchPath walks expected and actual in parallel, descending into
+// maps and slices, and returns the JSON pointer path of the first value
+// where they diverge. path is the root path to report (normally "").
+func firstMismatchPath(expected, actual interface{}, path string) string {
+	if path == "" {
+		path = "/"
+	}
+	if reflect.DeepEqual(expected, actual) {
+		return ""
+	}
+
+	switch e := expected.(type) {
+	case map[string]interface{}:
+		a, ok := actual.(map[string]interface{})
+		if !ok {
+			return trimTrailingSlash(path)
+		}
+		keys := make([]string, 0, len(e))
+		for k := range e {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			av, present := a[k]
+			if !present {
+				return path + k
+			}
+			if found := firstMismatchPath(e[k], av, path+k+"/"); found != "" {
+				return found
+			}
+		}
+		for k := range a {
+			if _, present := e[k]; !present {
+				return path + k
+			}
+		}
+		return trimTrailingSlash(path)
+	case []interface{}:
+		a, ok := actual.([]interface{})
+		if !ok {
+			return trimTrailingSlash(path)
+		}
+		for i := 0; i < len(e) && i < len(a); i++ {
+			if found := firstMismatchPath(e[i], a[i], fmt.Sprintf("%s%d/", path, i)); found != "" {
+				return found
+			}
+		}
+		firstMissing := len(e)
+		if len(a) < firstMissing {
+			firstMissing = len(a)
+		}
+		return fmt.Sprintf("%s%d", path, firstMissing)
+	default:
+		return trimTrailingSlash(path)
+	}
+}
+
+// trimTrailingSlash strips the trailing "/" firstMismatchPath's traversal
+// leaves on a leaf path (e.g. "/users/2/name/" -> "/users/2/name"),
+// without collapsing the root path "/" itself to "".
+func trimTrailingSlash(path string) string {
+	if path == "/" {
+		return path
+	}
+	return strings.TrimSuffix(path, "/")
+}
+
+// ConditionFunc is a predicate polled by Eventually and Never.
+type ConditionFunc func() bool
+
+// ComparisonAssertion is the signature shared by two-value assertions such
+// as Equal and NotEqual, useful for plugging is's methods into
+// table-driven test cases.
+type ComparisonAssertion func(actual interface{}, expected interface{})
+
+// ValueAssertion is the signature shared by single-value assertions such
+// as Nil, NotNil, Zero and NotZero, useful for plugging is's methods into
+// table-driven test cases.
+type ValueAssertion func(o interface{})
+
+// Eventually polls condition every tick until it returns true or timeout
+// elapses, whichever comes first. It fails if the timeout elapses without
+// condition ever having returned true.
+func (is *Is) Eventually(condition ConditionFunc, timeout, tick time.Duration) {
+	is.TB.Helper()
+	if !poll(condition, timeout, tick) {
+		fail(is, "condition did not become true within timeout %v (tick %v)", timeout, tick)
+	}
+}
+
+// Never polls condition every tick for the duration of timeout and fails
+// as soon as it observes condition return true. It passes if the timeout
+// elapses without condition ever returning true.
+func (is *Is) Never(condition ConditionFunc, timeout, tick time.Duration) {
+	is.TB.Helper()
+	if poll(condition, timeout, tick) {
+		fail(is, "condition became true within timeout %v (tick %v), but expected it never to", timeout, tick)
+	}
+}
+
+// poll checks condition immediately, then every tick thereafter, until it
+// returns true or timeout elapses, reporting whether it observed true. The
+// immediate check means an already-true condition succeeds regardless of
+// how tick compares to timeout. Each call to condition runs in its own
+// goroutine so a slow or hanging call can't delay the deadline; its
+// result is delivered over a buffered channel so that goroutine can
+// finish and exit even if poll has already returned.
+func poll(condition ConditionFunc, timeout, tick time.Duration) bool {
+	deadline := time.After(timeout)
+
+	if ok, timedOut := pollOnce(condition, deadline); ok || timedOut {
+		return ok
+	}
+
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+
 	for {
 		select {
-		case <-after:
-			fail(is, "function did not return true within the timeout of %v", timeout)
-			return
+		case <-deadline:
+			return false
+		case <-ticker.C:
+			if ok, timedOut := pollOnce(condition, deadline); ok || timedOut {
+				return ok
+			}
+		}
+	}
+}
+
+// pollOnce runs condition in its own goroutine so a slow or hanging call
+// can't delay the deadline, and reports whether it observed true before
+// the deadline elapsed.
+func pollOnce(condition ConditionFunc, deadline <-chan time.Time) (ok bool, timedOut bool) {
+	result := make(chan bool, 1)
+	go func() { result <- condition() }()
+	select {
+	case r := <-result:
+		return r, false
+	case <-deadline:
+		return false, true
+	}
+}
+
+// WaitForTrue waits until the provided func returns true, polling every
+// 100ms. If the timeout is reached before the function returns true, the
+// test will fail.
+//
+// Deprecated: use Eventually, which accepts a configurable tick interval.
+func (is *Is) WaitForTrue(timeout time.Duration, f func() bool) {
+	is.TB.Helper()
+	is.Eventually(f, timeout, 100*time.Millisecond)
+}
+
+// maxDiffLines caps how many lines of a pretty-printed value are considered
+// for diffing. Values that render larger than this are truncated with a
+// tail marker so a single huge structure can't blow up a test failure's
+// output.
+const maxDiffLines = 500
+
+// diffSuffix returns a "\n"-prefixed unified diff of expected and actual
+// when diffing is enabled and at least one of them is a kind worth
+// diffing (multi-line string, struct, slice, array or map). It returns an
+// empty string otherwise, or when the two pretty-printed values are
+// identical, so callers can append it directly to a format string.
+func (is *Is) diffSuffix(actual, expected interface{}) string {
+	if !is.diff || !(diffable(actual) || diffable(expected)) {
+		return ""
+	}
+	d := diff(expected, actual)
+	if d == "" {
+		return ""
+	}
+	return "\n" + d
+}
+
+// diffable reports whether v is a kind of value worth pretty-printing and
+// diffing rather than printing inline: a multi-line string, or a struct,
+// slice, array or map (looking through any pointer). Single-line strings
+// and other scalars keep the existing one-line got/expected format.
+func diffable(v interface{}) bool {
+	if v == nil {
+		return false
+	}
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return false
+		}
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.String:
+		// Single-line strings keep the existing one-line got/expected
+		// format; only multi-line strings are worth a line-by-line diff.
+		return strings.Contains(rv.String(), "\n")
+	case reflect.Struct, reflect.Slice, reflect.Array, reflect.Map:
+		return true
+	default:
+		return false
+	}
+}
+
+// diff renders expected and actual with prettyPrint and returns a unified
+// diff between the two, in the conventional "--- expected"/"+++ actual"
+// form with three lines of context around each change. It returns an empty
+// string when the two pretty-printed representations are identical.
+func diff(expected, actual interface{}) string {
+	expectedLines := splitLines(prettyPrint(expected))
+	actualLines := splitLines(prettyPrint(actual))
+	ops := diffLines(expectedLines, actualLines)
+
+	changed := false
+	for _, op := range ops {
+		if op.kind != ' ' {
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("--- expected\n+++ actual\n")
+	for _, hunk := range diffHunks(ops, 3) {
+		for _, op := range hunk {
+			b.WriteByte(op.kind)
+			b.WriteString(op.line)
+			b.WriteByte('\n')
+		}
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func splitLines(s string) []string {
+	lines := strings.Split(s, "\n")
+	if len(lines) <= maxDiffLines {
+		return lines
+	}
+	truncated := append([]string{}, lines[:maxDiffLines]...)
+	return append(truncated, fmt.Sprintf("... (truncated %d more lines)", len(lines)-maxDiffLines))
+}
+
+// diffLine is one line of a diff: kind is ' ' for a line common to both
+// sides, '-' for a line only in expected, or '+' for a line only in actual.
+type diffLine struct {
+	kind byte
+	line string
+}
+
+// diffLines computes a line-level diff between a and b using the standard
+// longest-common-subsequence backtrack (the same technique behind Myers'
+// algorithm), producing the minimal set of additions and removals.
+func diffLines(a, b []string) []diffLine {
+	n, m := len(a), len(b)
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	ops := make([]diffLine, 0, n+m)
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, diffLine{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, diffLine{'-', a[i]})
+			i++
 		default:
-			if f() {
-				return
+			ops = append(ops, diffLine{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		ops = append(ops, diffLine{'-', a[i]})
+	}
+	for ; j < m; j++ {
+		ops = append(ops, diffLine{'+', b[j]})
+	}
+	return ops
+}
+
+// diffHunks groups a diff's lines into unified-diff hunks, each padded
+// with up to context lines of unchanged lines on either side of a change.
+func diffHunks(ops []diffLine, context int) [][]diffLine {
+	var hunks [][]diffLine
+	n := len(ops)
+	for i := 0; i < n; {
+		if ops[i].kind == ' ' {
+			i++
+			continue
+		}
+		start := i - context
+		if start < 0 {
+			start = 0
+		}
+		lastChange, end := i, i+1
+		for end < n {
+			if ops[end].kind != ' ' {
+				lastChange = end
+			} else if end-lastChange > 2*context {
+				break
 			}
-			time.Sleep(100 * time.Millisecond)
+			end++
+		}
+		end = lastChange + context + 1
+		if end > n {
+			end = n
+		}
+		hunks = append(hunks, ops[start:end])
+		i = end
+	}
+	return hunks
+}
+
+// prettyPrint renders v as a deterministic, human-readable multi-line
+// representation: map keys are sorted, struct fields keep their declared
+// order, and nested values are indented one level per level of nesting.
+func prettyPrint(v interface{}) string {
+	if v == nil {
+		return "<nil>"
+	}
+	return prettyPrintValue(reflect.ValueOf(v), 0)
+}
+
+func prettyPrintValue(v reflect.Value, indent int) string {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "<nil>"
+		}
+		v = v.Elem()
+	}
+	pad := strings.Repeat("  ", indent)
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Struct:
+		t := v.Type()
+		if t.NumField() == 0 {
+			return t.Name() + "{}"
 		}
+		var b strings.Builder
+		fmt.Fprintf(&b, "%s{\n", t.Name())
+		for i := 0; i < t.NumField(); i++ {
+			fmt.Fprintf(&b, "%s  %s: %s\n", pad, t.Field(i).Name, prettyPrintValue(v.Field(i), indent+1))
+		}
+		fmt.Fprintf(&b, "%s}", pad)
+		return b.String()
+	case reflect.Slice, reflect.Array:
+		if v.Len() == 0 {
+			return "[]"
+		}
+		var b strings.Builder
+		b.WriteString("[\n")
+		for i := 0; i < v.Len(); i++ {
+			fmt.Fprintf(&b, "%s  %d: %s\n", pad, i, prettyPrintValue(v.Index(i), indent+1))
+		}
+		fmt.Fprintf(&b, "%s]", pad)
+		return b.String()
+	case reflect.Map:
+		keys := v.MapKeys()
+		if len(keys) == 0 {
+			return "{}"
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			return formatScalar(keys[i]) < formatScalar(keys[j])
+		})
+		var b strings.Builder
+		b.WriteString("{\n")
+		for _, k := range keys {
+			fmt.Fprintf(&b, "%s  %s: %s\n", pad, formatScalar(k), prettyPrintValue(v.MapIndex(k), indent+1))
+		}
+		fmt.Fprintf(&b, "%s}", pad)
+		return b.String()
+	default:
+		return formatScalar(v)
+	}
+}
+
+// formatScalar renders v as a string without requiring v.Interface(),
+// which panics for values obtained from an unexported struct field. It
+// falls back to the typed reflect accessors for the kinds prettyPrint can
+// encounter as a leaf or map key.
+func formatScalar(v reflect.Value) string {
+	if v.CanInterface() {
+		return fmt.Sprintf("%v", v.Interface())
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return fmt.Sprintf("%v", v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return fmt.Sprintf("%v", v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return fmt.Sprintf("%v", v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return fmt.Sprintf("%v", v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		return fmt.Sprintf("%v", v.Complex())
+	default:
+		return fmt.Sprintf("<unexported %s>", v.Type())
 	}
 }
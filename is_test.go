@@ -4,8 +4,10 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 
 	"testing"
+	"time"
 )
 
 var numberTypes = []reflect.Type{
@@ -182,3 +184,223 @@ func TestIsAfter(t *testing.T) {
 	}
 
 }
+
+func TestInDeltaAndInEpsilon(t *testing.T) {
+	is := New(t)
+
+	is.InDelta(1.001, 1.0, 0.01)
+	is.InDelta(99, 100, 2)
+	is.InEpsilon(1.01, 1.0, 0.02)
+	is.InEpsilon(float32(1.0), float32(1.0), 0.0001)
+
+	failed := false
+	lax := is.Lax()
+	lax.fail = func(format string, args ...interface{}) { failed = true }
+	lax.InDelta(1.5, 1.0, 0.1)
+	if !failed {
+		t.Fatal("expected InDelta to fail when difference exceeds delta")
+	}
+
+	failed = false
+	lax.InEpsilon(1.0, 0, 0.1)
+	if !failed {
+		t.Fatal("expected InEpsilon to fail against an expected value of zero")
+	}
+
+	is.InDeltaSlice([]float64{1.001, 2.002}, []float64{1.0, 2.0}, 0.01)
+	is.InEpsilonSlice([]float64{1.01, 2.02}, []float64{1.0, 2.0}, 0.02)
+}
+
+func TestCollectionAssertions(t *testing.T) {
+	is := New(t)
+
+	is.Contains("hello world", "world")
+	is.NotContains("hello world", "bye")
+	is.Contains([]int64{1, 2, 3}, int64(2))
+	is.NotContains([]int64{1, 2, 3}, int64(4))
+	is.Contains(map[string]int64{"a": 1}, "a")
+	is.NotContains(map[string]int64{"a": 1}, "b")
+
+	is.ElementsMatch([]int64{1, 2, 3}, []int64{3, 2, 1})
+
+	failed := false
+	lax := is.Lax()
+	lax.fail = func(format string, args ...interface{}) { failed = true }
+	lax.ElementsMatch([]int64{1, 2, 3}, []int64{1, 2})
+	if !failed {
+		t.Fatal("expected ElementsMatch to fail when lists differ")
+	}
+
+	is.Subset([]int64{1, 2, 3}, []int64{1, 3})
+	is.Subset(map[string]int64{"a": 1, "b": 2}, map[string]int64{"a": 1})
+
+	failed = false
+	lax.fail = func(format string, args ...interface{}) { failed = true }
+	lax.Subset([]int64{1, 2, 3}, []int64{4})
+	if !failed {
+		t.Fatal("expected Subset to fail when sub has elements missing from super")
+	}
+
+	is.NotSubset([]int64{1, 2, 3}, []int64{4})
+}
+
+func TestErrorAssertions(t *testing.T) {
+	is := New(t)
+
+	sentinel := errors.New("sentinel")
+	wrapped := fmt.Errorf("wrapping: %w", sentinel)
+
+	is.ErrorIs(wrapped, sentinel)
+	is.ErrorContains(wrapped, "wrapping")
+
+	var target *testErr
+	is.ErrorAs(fmt.Errorf("wrap: %w", &testErr{msg: "boom"}), &target)
+	is.Equal(target.msg, "boom")
+
+	is.PanicsWithValue("boom", func() { panic("boom") })
+	is.PanicsWithError("boom", func() { panic(errors.New("boom")) })
+}
+
+type testErr struct{ msg string }
+
+func (e *testErr) Error() string { return e.msg }
+
+func TestJSONEqAndYAMLEq(t *testing.T) {
+	is := New(t)
+
+	is.JSONEq(`{"a":1,"b":[1,2,3]}`, `{"b":[1,2,3],"a":1}`)
+
+	failed := false
+	lax := is.Lax()
+	lax.fail = func(format string, args ...interface{}) { failed = true }
+	lax.JSONEq(`{"a":1}`, `{"a":2}`)
+	if !failed {
+		t.Fatal("expected JSONEq to fail for structurally different JSON")
+	}
+
+	failed = false
+	lax.fail = func(format string, args ...interface{}) { failed = true }
+	lax.YAMLEq("a: 1", "a: 1")
+	if !failed {
+		t.Fatal("expected YAMLEq to fail without a registered YAML unmarshal func")
+	}
+}
+
+func TestFirstMismatchPath(t *testing.T) {
+	expected := map[string]interface{}{
+		"users": []interface{}{
+			map[string]interface{}{"name": "a"},
+			map[string]interface{}{"name": "b"},
+			map[string]interface{}{"name": "c"},
+		},
+	}
+	actual := map[string]interface{}{
+		"users": []interface{}{
+			map[string]interface{}{"name": "a"},
+			map[string]interface{}{"name": "b"},
+			map[string]interface{}{"name": "different"},
+		},
+	}
+
+	is := New(t)
+	is.Equal(firstMismatchPath(expected, actual, ""), "/users/2/name")
+}
+
+func TestFirstMismatchPathLengthMismatch(t *testing.T) {
+	is := New(t)
+	expected := map[string]interface{}{"x": []interface{}{1.0, 2.0, 3.0}}
+	actual := map[string]interface{}{"x": []interface{}{1.0, 2.0}}
+	is.Equal(firstMismatchPath(expected, actual, ""), "/x/2")
+}
+
+func TestEventuallyAndNever(t *testing.T) {
+	is := New(t)
+
+	calls := 0
+	is.Eventually(func() bool {
+		calls++
+		return calls >= 3
+	}, time.Second, 10*time.Millisecond)
+
+	is.Never(func() bool { return false }, 50*time.Millisecond, 10*time.Millisecond)
+
+	failed := false
+	lax := is.Lax()
+	lax.fail = func(format string, args ...interface{}) { failed = true }
+	lax.Eventually(func() bool { return false }, 30*time.Millisecond, 10*time.Millisecond)
+	if !failed {
+		t.Fatal("expected Eventually to fail when condition never becomes true")
+	}
+
+	failed = false
+	lax.fail = func(format string, args ...interface{}) { failed = true }
+	lax.Never(func() bool { return true }, 50*time.Millisecond, 10*time.Millisecond)
+	if !failed {
+		t.Fatal("expected Never to fail when condition becomes true")
+	}
+
+	is.WaitForTrue(time.Second, func() bool { return true })
+
+	// A condition that is already true must succeed immediately, even when
+	// tick is as long as (or longer than) timeout.
+	is.Eventually(func() bool { return true }, 30*time.Millisecond, 500*time.Millisecond)
+}
+
+func TestDiff(t *testing.T) {
+	d := diff(testStruct{v: 1}, testStruct{v: 2})
+	if !strings.Contains(d, "--- expected") || !strings.Contains(d, "+++ actual") {
+		t.Fatalf("expected a unified diff header, got: %s", d)
+	}
+	if !strings.Contains(d, "-  v: 1") || !strings.Contains(d, "+  v: 2") {
+		t.Fatalf("expected diff to call out the changed field, got: %s", d)
+	}
+
+	if d := diff(testStruct{v: 1}, testStruct{v: 1}); d != "" {
+		t.Fatalf("expected no diff for identical values, got: %s", d)
+	}
+
+	if diffable(42) {
+		t.Fatal("expected scalar int not to be diffable")
+	}
+	if !diffable(testStruct{}) {
+		t.Fatal("expected struct to be diffable")
+	}
+	if diffable("foo") {
+		t.Fatal("expected a single-line string not to be diffable")
+	}
+	if !diffable("foo\nbar") {
+		t.Fatal("expected a multi-line string to be diffable")
+	}
+}
+
+type testStructWithMap struct {
+	m map[string]int
+}
+
+func TestDiffUnexportedFields(t *testing.T) {
+	// Must not panic: testStruct.v is unexported, so rendering it as a
+	// diff leaf can't go through reflect.Value.Interface().
+	d := diff(testStruct{v: 1}, testStruct{v: 2})
+	if !strings.Contains(d, "1") || !strings.Contains(d, "2") {
+		t.Fatalf("expected diff to render unexported scalar field, got: %s", d)
+	}
+
+	d = diff(testStructWithMap{m: map[string]int{"a": 1}}, testStructWithMap{m: map[string]int{"a": 2}})
+	if !strings.Contains(d, "a") {
+		t.Fatalf("expected diff to render unexported map field, got: %s", d)
+	}
+}
+
+func TestEqualSingleLineStringHasNoDiff(t *testing.T) {
+	is := New(t)
+
+	var msg string
+	lax := is.Lax()
+	lax.fail = func(format string, args ...interface{}) {
+		msg = fmt.Sprintf(format, args...)
+	}
+	lax.Equal("foo", "bar")
+	if strings.Contains(msg, "--- expected") {
+		t.Fatalf("expected single-line string mismatch not to include a diff block, got: %s", msg)
+	}
+}